@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// Category groups related CLIError codes together so that scripts can branch
+// on the broad shape of a failure (e.g. "retry on category=network") without
+// having to enumerate every code we might ever introduce.
+type Category string
+
+const (
+	CategoryConfig   Category = "config"
+	CategorySnapshot Category = "snapshot"
+	CategoryProvider Category = "provider"
+	CategoryNetwork  Category = "network"
+	CategoryPlugin   Category = "plugin"
+	CategoryGeneric  Category = "generic"
+)
+
+// CLIError is implemented by errors the CLI can classify into a stable,
+// scriptable taxonomy: a short machine-readable code, a broader category, and
+// a human-readable remediation describing how to recover. Errors that
+// implement CLIError, and the errors classifyCLIError wraps in one, are
+// eligible for the structured envelope emitted by processCmdErrors when
+// --error-format=json is set; all other errors continue to be reported as
+// freeform diagnostics.
+type CLIError interface {
+	error
+
+	// Code is a short, stable identifier for this class of error, e.g.
+	// "decrypt_failed". Codes are never repurposed once released.
+	Code() string
+	// Category groups related codes, e.g. CategoryConfig, CategorySnapshot.
+	Category() Category
+	// Remediation is a human-readable suggestion for resolving the error,
+	// typically naming a concrete `pulumi` command to run. May be empty if
+	// there is no general-purpose fix.
+	Remediation() string
+	// Details carries additional structured context about the error, e.g.
+	// the config key that failed to decrypt. May be nil.
+	Details() map[string]string
+	// Unwrap exposes the underlying error for errors.Is/errors.As callers.
+	Unwrap() error
+}
+
+// cliError is the concrete implementation shared by all of the typed
+// wrappers below. It is unexported: callers construct instances through the
+// newXxxCLIError constructors so that code/category/remediation stay
+// consistent for a given failure class.
+type cliError struct {
+	code        string
+	category    Category
+	remediation string
+	details     map[string]string
+	cause       error
+}
+
+func (e *cliError) Error() string              { return e.cause.Error() }
+func (e *cliError) Unwrap() error              { return e.cause }
+func (e *cliError) Code() string               { return e.code }
+func (e *cliError) Category() Category         { return e.category }
+func (e *cliError) Remediation() string        { return e.remediation }
+func (e *cliError) Details() map[string]string { return e.details }
+
+func newDecryptCLIError(e engine.DecryptError) CLIError {
+	return &cliError{
+		code:        "decrypt_failed",
+		category:    CategoryConfig,
+		remediation: fmt.Sprintf("re-encrypt the value with `pulumi config set %s [value] --secret` against the current stack", e.Key),
+		details:     map[string]string{"key": e.Key},
+		cause:       e,
+	}
+}
+
+func newSnapshotIntegrityCLIError(cause error) CLIError {
+	return &cliError{
+		code:        "snapshot_integrity",
+		category:    CategorySnapshot,
+		remediation: "run `pulumi stack export` and inspect the checkpoint, or restore a prior version with `pulumi stack history` / `pulumi stack export --version`",
+		cause:       cause,
+	}
+}
+
+func newProviderAuthCLIError(cause error) CLIError {
+	return &cliError{
+		code:        "provider_auth_failed",
+		category:    CategoryProvider,
+		remediation: "check that the provider's credentials are configured, e.g. via `pulumi config set` or the provider's environment variables",
+		cause:       cause,
+	}
+}
+
+func newNetworkCLIError(cause error) CLIError {
+	return &cliError{
+		code:        "network_timeout",
+		category:    CategoryNetwork,
+		remediation: "check your network connection and the reachability of the Pulumi service or provider endpoint, then retry",
+		cause:       cause,
+	}
+}
+
+func newPluginMissingCLIError(cause error) CLIError {
+	return &cliError{
+		code:        "plugin_missing",
+		category:    CategoryPlugin,
+		remediation: "run `pulumi plugin install` to install the missing plugin",
+		cause:       cause,
+	}
+}
+
+// classifyCLIError attempts to recognize err as one of the failure classes
+// the CLI knows about today, returning a CLIError wrapper and true if it
+// does. It returns false for errors we have no specific classification for,
+// which callers should fall back to generic handling for.
+//
+// Scope note: config-missing (a stack referencing a configuration key that
+// has no value set) is part of the target taxonomy but isn't classified
+// here yet. Doing so needs a sentinel from the project/stack config loader,
+// which this package doesn't have a dependency on today; tracked as a
+// follow-up rather than guessed at.
+func classifyCLIError(err error) (CLIError, bool) {
+	var cliErr CLIError
+	if errors.As(err, &cliErr) {
+		return cliErr, true
+	}
+
+	if de, ok := engine.AsDecryptError(err); ok {
+		return newDecryptCLIError(*de), true
+	}
+
+	if _, ok := engine.AsSnapshotIntegrityError(err); ok {
+		return newSnapshotIntegrityCLIError(err), true
+	}
+
+	var missing *workspace.MissingError
+	if errors.As(err, &missing) {
+		return newPluginMissingCLIError(err), true
+	}
+
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Unauthenticated, codes.PermissionDenied:
+			return newProviderAuthCLIError(err), true
+		case codes.DeadlineExceeded, codes.Unavailable:
+			return newNetworkCLIError(err), true
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return newNetworkCLIError(err), true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,52 @@
+// Package errhelp provides a small, pluggable registry of handlers that
+// render friendly, actionable explanations for specific classes of error
+// that the Pulumi CLI knows about. It exists so that `cmd/pulumi` doesn't
+// accumulate one-off `printXxxError` helpers each time we want to give the
+// user a more helpful message than the raw error text: each failure class
+// registers a matcher that recognizes it and a renderer that writes the
+// explanation, and callers consult the whole registry through Render.
+package errhelp
+
+import "io"
+
+// Matcher reports whether err belongs to the failure class a handler knows
+// how to explain. Matchers are typically a type assertion or an errors.As
+// check against a specific error type.
+type Matcher func(err error) bool
+
+// Renderer writes a friendly explanation of err, including any suggested
+// remediation, to w. It is only ever called with an err for which the
+// corresponding Matcher returned true.
+type Renderer func(w io.Writer, err error)
+
+// handler pairs a Matcher with the Renderer to invoke when it matches.
+type handler struct {
+	matcher  Matcher
+	renderer Renderer
+}
+
+// handlers is the process-wide registry of known error classes, consulted
+// in registration order by Render.
+var handlers []handler
+
+// Register adds a new handler to the registry. Handlers are consulted in
+// the order they were registered, so more specific matchers should be
+// registered before more general ones that might also match the same error.
+func Register(matcher Matcher, renderer Renderer) {
+	handlers = append(handlers, handler{matcher: matcher, renderer: renderer})
+}
+
+// Render looks for the first registered handler whose matcher recognizes
+// err, and if one is found, invokes its renderer to write a friendly
+// explanation to w and returns true. If no handler recognizes err, Render
+// writes nothing and returns false, leaving the caller to fall back to
+// generic error handling.
+func Render(w io.Writer, err error) bool {
+	for _, h := range handlers {
+		if h.matcher(err) {
+			h.renderer(w, err)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package errhelp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fooError struct{}
+
+func (fooError) Error() string { return "foo" }
+
+type barError struct{}
+
+func (barError) Error() string { return "bar" }
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	// Reset the package-level registry so this test isn't order-dependent
+	// on other tests registering handlers via init().
+	handlers = nil
+
+	Register(
+		func(err error) bool { var e fooError; return errors.As(err, &e) },
+		func(w io.Writer, err error) { _, _ = w.Write([]byte("handled foo")) },
+	)
+
+	t.Run("matching handler fires", func(t *testing.T) {
+		var buf bytes.Buffer
+		handled := Render(&buf, fooError{})
+		assert.True(t, handled)
+		assert.Equal(t, "handled foo", buf.String())
+	})
+
+	t.Run("unknown error falls through", func(t *testing.T) {
+		var buf bytes.Buffer
+		handled := Render(&buf, barError{})
+		assert.False(t, handled)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("handlers are consulted in registration order", func(t *testing.T) {
+		handlers = nil
+		Register(
+			func(err error) bool { return true },
+			func(w io.Writer, err error) { _, _ = w.Write([]byte("first")) },
+		)
+		Register(
+			func(err error) bool { return true },
+			func(w io.Writer, err error) { _, _ = w.Write([]byte("second")) },
+		)
+
+		var buf bytes.Buffer
+		handled := Render(&buf, barError{})
+		assert.True(t, handled)
+		assert.Equal(t, "first", buf.String())
+	})
+}
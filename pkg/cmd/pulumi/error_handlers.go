@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"io"
+
+	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/errhelp"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// init registers the errhelp handlers for the error classes the CLI already
+// knows how to give the user a more helpful explanation for. Handlers are
+// consulted in registration order, so register the most specific matchers
+// first.
+func init() {
+	errhelp.Register(decryptErrorMatcher, renderDecryptError)
+	errhelp.Register(snapshotIntegrityErrorMatcher, renderSnapshotIntegrityError)
+	errhelp.Register(pluginMissingErrorMatcher, renderPluginMissingError)
+}
+
+func decryptErrorMatcher(err error) bool {
+	_, ok := engine.AsDecryptError(err)
+	return ok
+}
+
+// renderDecryptError writes out help text containing common causes of and
+// possible resolutions for decryption errors. This is the same text
+// previously hard-coded in printDecryptError.
+func renderDecryptError(w io.Writer, err error) {
+	e, _ := engine.AsDecryptError(err)
+	fprintf(w, "failed to decrypt encrypted configuration value '%s': %s", e.Key, e.Err)
+	fprintf(w, ""+
+		"This can occur when a secret is copied from one stack to another. Encryption of secrets is done per-stack and "+
+		"it is not possible to share an encrypted configuration value across stacks.\n"+
+		"\n"+
+		"You can re-encrypt your configuration by running `pulumi config set %s [value] --secret` with your "+
+		"new stack selected.\n"+
+		"\n"+
+		"refusing to proceed", e.Key)
+}
+
+func snapshotIntegrityErrorMatcher(err error) bool {
+	_, ok := engine.AsSnapshotIntegrityError(err)
+	return ok
+}
+
+// renderSnapshotIntegrityError explains that the stack's checkpoint failed
+// an invariant check, and points the user at the stack export/import
+// commands they can use to inspect or repair it.
+func renderSnapshotIntegrityError(w io.Writer, err error) {
+	e, _ := engine.AsSnapshotIntegrityError(err)
+	fprintf(w, "the stack's checkpoint is invalid: %s", e)
+	fprintf(w, ""+
+		"This usually means that the stack's checkpoint file was modified or written by an incompatible version of "+
+		"the Pulumi engine.\n"+
+		"\n"+
+		"You can inspect the checkpoint with `pulumi stack export`, or restore a known-good version with "+
+		"`pulumi stack export --version <version> | pulumi stack import`.\n"+
+		"\n"+
+		"refusing to proceed")
+}
+
+func pluginMissingErrorMatcher(err error) bool {
+	var missing *workspace.MissingError
+	return errors.As(err, &missing)
+}
+
+// renderPluginMissingError explains that a resource plugin required by the
+// program could not be found, and points the user at `pulumi plugin
+// install`.
+func renderPluginMissingError(w io.Writer, err error) {
+	var missing *workspace.MissingError
+	errors.As(err, &missing)
+	fprintf(w, "%s", missing.Error())
+	fprintf(w, ""+
+		"This occurs when the plugin required by your program has not been installed, or has been installed to a "+
+		"different location than the CLI expects.\n"+
+		"\n"+
+		"You can install the missing plugin by running `pulumi plugin install`.\n"+
+		"\n"+
+		"refusing to proceed")
+}
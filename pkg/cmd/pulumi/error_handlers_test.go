@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/errhelp"
+)
+
+func TestErrhelpHandlers(t *testing.T) {
+	t.Run("decrypt error routes to the decrypt renderer", func(t *testing.T) {
+		err := engine.DecryptError{Key: "myStack:secret", Err: errors.New("ciphertext mismatch")}
+
+		var buf bytes.Buffer
+		handled := errhelp.Render(&buf, err)
+
+		assert.True(t, handled)
+		assert.Contains(t, buf.String(), "failed to decrypt encrypted configuration value 'myStack:secret'")
+		assert.Contains(t, buf.String(), "pulumi config set myStack:secret")
+	})
+
+	t.Run("snapshot integrity error routes to the snapshot renderer", func(t *testing.T) {
+		err := engine.SnapshotIntegrityError{Err: errors.New("invalid resource dependency")}
+
+		var buf bytes.Buffer
+		handled := errhelp.Render(&buf, err)
+
+		assert.True(t, handled)
+		assert.Contains(t, buf.String(), "checkpoint is invalid")
+		assert.Contains(t, buf.String(), "pulumi stack export")
+	})
+
+	t.Run("missing plugin error routes to the plugin renderer", func(t *testing.T) {
+		err := &workspace.MissingError{}
+
+		var buf bytes.Buffer
+		handled := errhelp.Render(&buf, err)
+
+		assert.True(t, handled)
+		assert.Contains(t, buf.String(), "pulumi plugin install")
+	})
+
+	t.Run("unrecognized errors fall through unhandled", func(t *testing.T) {
+		err := errors.New("some unrelated failure")
+
+		var buf bytes.Buffer
+		handled := errhelp.Render(&buf, err)
+
+		assert.False(t, handled)
+		assert.Empty(t, buf.String())
+	})
+}
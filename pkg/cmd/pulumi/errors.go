@@ -3,10 +3,13 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"os"
 
-	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/errhelp"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
@@ -14,6 +17,75 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// errorFormat controls how processCmdErrors renders errors it cannot (or, in
+// text mode, chooses not to) fully absorb into a friendly message. It is
+// bound to the global `--error-format` flag registered by
+// AddErrorFormatFlag, which NewPulumiCmd calls when building the root
+// command.
+var errorFormat = "text"
+
+// AddErrorFormatFlag registers the `--error-format` persistent flag on the
+// root command. In "text" mode (the default) errors are reported as
+// freeform, human-oriented diagnostics, matching today's behavior. In "json"
+// mode, any error that classifyCLIError recognizes (including any error that
+// implements CLIError) is instead reported on stderr as a single-line,
+// stable JSON envelope intended for CI and other scripted callers of the CLI.
+func AddErrorFormatFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(
+		&errorFormat, "error-format", "text",
+		"Set the format in which errors are reported: 'text' (default) or 'json'")
+}
+
+// validatedErrorFormat returns the requested --error-format, falling back to
+// "text" (and emitting a warning on each call) if the flag was given a value
+// we don't recognize, so a typo like --error-format=jso degrades, with a
+// warning, to the human-readable default rather than silently changing
+// output in a way a script might not notice.
+func validatedErrorFormat() string {
+	switch errorFormat {
+	case "text", "json":
+		return errorFormat
+	default:
+		cmdutil.Diag().Warningf(diag.Message("", "invalid --error-format %q, falling back to 'text'"), errorFormat)
+		return "text"
+	}
+}
+
+// cliErrorEnvelope is the stable, machine-readable shape written to stderr
+// for CLIErrors when --error-format=json is set. Field names and meanings
+// are part of the CLI's scripting surface and should not change casually.
+type cliErrorEnvelope struct {
+	Code        string            `json:"code"`
+	Category    Category          `json:"category"`
+	Message     string            `json:"message"`
+	Remediation string            `json:"remediation,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+	Causes      []string          `json:"causes,omitempty"`
+}
+
+// writeCLIErrorJSON emits e as a cliErrorEnvelope on stderr, including the
+// chain of causes below e.Unwrap(). e.Error() is itself defined in terms of
+// that first unwrap (see cliError.Error), so the chain starts one level
+// deeper to avoid duplicating Message as causes[0].
+func writeCLIErrorJSON(e CLIError) {
+	var causes []string
+	for cause := errors.Unwrap(e.Unwrap()); cause != nil; cause = errors.Unwrap(cause) {
+		causes = append(causes, cause.Error())
+	}
+
+	envelope := cliErrorEnvelope{
+		Code:        e.Code(),
+		Category:    e.Category(),
+		Message:     e.Error(),
+		Remediation: e.Remediation(),
+		Details:     e.Details(),
+		Causes:      causes,
+	}
+
+	enc := json.NewEncoder(os.Stderr)
+	contract.IgnoreError(enc.Encode(envelope))
+}
+
 // runCmdFunc wraps cmdutil.RunFunc. While cmdutil.RunFunc provides a standard
 // wrapper for dealing with and logging errors before exiting with an
 // appropriate error code, runCmdFunc extends this with additional error
@@ -45,32 +117,44 @@ func processCmdErrors(err error) error {
 		return err
 	}
 
-	// Other type-specific error handling.
-	if de, ok := engine.AsDecryptError(err); ok {
-		printDecryptError(*de)
-		return nil
-	}
+	cliErr, classified := classifyCLIError(err)
 
-	// In all other cases, return the unexpected error as-is for generic handling.
-	return err
-}
+	// If we can classify this error into our known taxonomy and the caller
+	// asked for machine-readable output, report it as a stable JSON envelope.
+	// We've already told the user everything we can, so turn it into a bail
+	// error rather than returning nil outright: this still signals a
+	// failing exit code to scripts checking `$?`, without printing the error
+	// a second time. (The text-mode branch below returns nil once it has
+	// reported the error.)
+	if validatedErrorFormat() == "json" {
+		if classified {
+			writeCLIErrorJSON(cliErr)
+			return result.BailErrorf("%s", cliErr.Code())
+		}
+		return err
+	}
 
-// A type-specific handler for engine.DecryptErrors that prints out help text
-// containing common causes of and possible resolutions for decryption errors.
-func printDecryptError(e engine.DecryptError) {
+	// For today's default text output, consult the errhelp registry for a
+	// friendly, type-specific explanation of the error. If none of the
+	// registered handlers recognize it, fall back to a generic report built
+	// from the classification above (if any), and failing that, to the
+	// unexpected-error path.
 	var buf bytes.Buffer
 	writer := bufio.NewWriter(&buf)
-	fprintf(writer, "failed to decrypt encrypted configuration value '%s': %s", e.Key, e.Err)
-	fprintf(writer, ""+
-		"This can occur when a secret is copied from one stack to another. Encryption of secrets is done per-stack and "+
-		"it is not possible to share an encrypted configuration value across stacks.\n"+
-		"\n"+
-		"You can re-encrypt your configuration by running `pulumi config set %s [value] --secret` with your "+
-		"new stack selected.\n"+
-		"\n"+
-		"refusing to proceed", e.Key)
+	switch {
+	case errhelp.Render(writer, err):
+	case classified:
+		fprintf(writer, "%s", cliErr.Error())
+		if remediation := cliErr.Remediation(); remediation != "" {
+			fprintf(writer, "\n\n%s", remediation)
+		}
+	default:
+		return err
+	}
+
 	contract.IgnoreError(writer.Flush())
 	cmdutil.Diag().Errorf(diag.RawMessage("" /*urn*/, buf.String()))
+	return nil
 }
 
 // Quick and dirty utility function for printing to writers that we know will never fail.
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAddErrorFormatFlag proves that --error-format is actually registered
+// on the real root command built by NewPulumiCmd, not just against some
+// throwaway cobra.Command that happens to call AddErrorFormatFlag itself.
+func TestAddErrorFormatFlag(t *testing.T) {
+	errorFormat = "text"
+	defer func() { errorFormat = "text" }()
+
+	cmd := NewPulumiCmd()
+	cmd.RunE = func(cmd *cobra.Command, args []string) error { return nil }
+
+	cmd.SetArgs([]string{"--error-format=json"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "json", errorFormat)
+}
+
+func TestValidatedErrorFormat(t *testing.T) {
+	defer func() { errorFormat = "text" }()
+
+	errorFormat = "json"
+	assert.Equal(t, "json", validatedErrorFormat())
+
+	errorFormat = "yaml"
+	assert.Equal(t, "text", validatedErrorFormat())
+}
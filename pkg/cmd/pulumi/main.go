@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+	"github.com/spf13/cobra"
+)
+
+// NewPulumiCmd builds the root `pulumi` command, registering the global
+// flags that apply across every subcommand.
+func NewPulumiCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "pulumi",
+		Short:         "Pulumi command line",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	AddErrorFormatFlag(cmd)
+
+	return cmd
+}
+
+func main() {
+	if err := NewPulumiCmd().Execute(); err != nil {
+		// Bail errors mean the error was already reported, either as a
+		// diagnostic or as a JSON envelope on stderr (see processCmdErrors);
+		// anything else hasn't been shown to the user yet, so print it here
+		// rather than exiting silently.
+		if !result.IsBail(err) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(1)
+	}
+}